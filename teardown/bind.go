@@ -0,0 +1,86 @@
+package teardown
+
+import "testing"
+
+/**
+ * resolveName defaults an empty name to t.Name(), so Bind/AddT/AddDiagnosticT can be used without
+ * callers having to invent a list name for a single test - while still allowing an explicit shared
+ * name (e.g. "DATABASE") across several AddT calls within the same test.
+ */
+func resolveName(name string, t testing.TB) string {
+	if name == "" {
+		return t.Name()
+	}
+
+	return name
+}
+
+/**
+ * Bind registers t.Cleanup(func(){ m.Run(name) }) exactly once per (t, name) pair, so a test never
+ * needs its own `defer teardown.Teardown(...)` line. Calling Bind again for the same (t, name) -
+ * e.g. from repeated AddT calls - is a no-op; the func() is registered with t.Cleanup only the
+ * first time.
+ */
+func (m *Manager) Bind(t testing.TB, name string) {
+	name = resolveName(name, t)
+
+	m.mu.Lock()
+	if m.bound[t] == nil {
+		m.bound[t] = make(map[string]bool)
+	}
+	if m.bound[t][name] {
+		m.mu.Unlock()
+		return
+	}
+	m.bound[t][name] = true
+	m.mu.Unlock()
+
+	t.Cleanup(func() { m.Run(name) })
+}
+
+/**
+ * AddT both registers fn against name (like Add) and binds name to t's Cleanup (like Bind), in one
+ * call - the usual way to use this package from a test that doesn't need to control exactly when
+ * teardown happens.
+ */
+func (m *Manager) AddT(t testing.TB, name string, fn func()) {
+	name = resolveName(name, t)
+
+	m.Add(name, fn)
+	m.Bind(t, name)
+}
+
+/**
+ * AddDiagnosticT is the diagnostic counterpart of AddT: it registers fn as a diagnostic teardown
+ * using t.Failed() as the condition (so it runs when t has failed, or always if
+ * AlwaysRunDiagnosticTeardowns is set), and binds name to t's Cleanup.
+ */
+func (m *Manager) AddDiagnosticT(t testing.TB, name string, fn func()) {
+	name = resolveName(name, t)
+
+	m.AddDiagnostic(name, func() bool { return t.Failed() }, fn)
+	m.Bind(t, name)
+}
+
+/**
+ * Bind registers t.Cleanup(func(){ Teardown(name) }) exactly once per (t, name) pair against the
+ * default Manager - see (*Manager).Bind.
+ */
+func Bind(t testing.TB, name string) {
+	defaultManager.Bind(t, name)
+}
+
+/**
+ * AddT both calls AddTeardown and Bind in one shot against the default Manager - see (*Manager).AddT.
+ */
+func AddT(t testing.TB, name string, fn func()) {
+	defaultManager.AddT(t, name, fn)
+}
+
+/**
+ * AddDiagnosticT is the diagnostic counterpart of AddT against the default Manager - see
+ * (*Manager).AddDiagnosticT.
+ */
+func AddDiagnosticT(t testing.TB, name string, fn func()) {
+	defaultManager.AddDiagnosticT(t, name, fn)
+}
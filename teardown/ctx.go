@@ -0,0 +1,150 @@
+package teardown
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+)
+
+/** TEARDOWN_TIMEOUT sets the default per-function deadline applied by AddTeardownCtx/RunWithContext. */
+const TEARDOWN_TIMEOUT = "TEARDOWN_TIMEOUT"
+
+/** defaultTimeout is used for any ctx-aware entry that doesn't set its own WithTimeout(d). */
+var defaultTimeout = 30 * time.Second
+
+func init() {
+	if d, err := time.ParseDuration(os.Getenv(TEARDOWN_TIMEOUT)); err == nil {
+		defaultTimeout = d
+	}
+}
+
+/** Option configures a single entry registered via AddTeardownCtx/AddDiagnosticTeardownCtx. */
+type Option func(*ctxTeardownFunc)
+
+/** WithTimeout overrides the default per-function deadline for one registered entry. */
+func WithTimeout(d time.Duration) Option {
+	return func(e *ctxTeardownFunc) {
+		e.timeout = d
+	}
+}
+
+/**
+ * ctxTeardownFunc is the internal representation every registered teardown func is normalised to,
+ * whether it was added via Add/AddDiagnostic (a plain func()) or AddCtx/AddDiagnosticCtx (a
+ * func(context.Context) error) - so Run and RunWithContext share one execution path. ctxAware
+ * records which of the two it was: only ctx-aware entries get the goroutine+deadline treatment in
+ * runEntryCtx, since a plain func() may call t.FailNow()/require.* (runtime.Goexit), which would
+ * never signal completion from inside a worker goroutine.
+ */
+type ctxTeardownFunc struct {
+	fn       func(context.Context) error
+	timeout  time.Duration
+	ctxAware bool
+}
+
+func newCtxEntry(fn func(context.Context) error, opts ...Option) ctxTeardownFunc {
+	entry := ctxTeardownFunc{fn: fn, timeout: defaultTimeout, ctxAware: true}
+
+	for _, opt := range opts {
+		opt(&entry)
+	}
+
+	return entry
+}
+
+/** newPlainEntry wraps an already-adapted plain func() as a non-ctx-aware entry, run synchronously by runEntryCtx. */
+func newPlainEntry(fn func(context.Context) error) ctxTeardownFunc {
+	return ctxTeardownFunc{fn: fn}
+}
+
+/** adaptPlainFunc lets a plain func() run through the same execution path as a ctx-aware entry. */
+func adaptPlainFunc(fn func()) func(context.Context) error {
+	return func(context.Context) error {
+		fn()
+		return nil
+	}
+}
+
+/**
+ * wrapDiagnosticCtx wraps a diagnostic teardown func: it evaluates condition at teardown time and
+ * only invokes fn if the diagnostic should run.
+ */
+func wrapDiagnosticCtx(condition interface{}, fn func(context.Context) error) func(context.Context) error {
+	return func(ctx context.Context) error {
+		if AlwaysRunDiagnosticTeardowns || evalCondition(condition) {
+			return fn(ctx)
+		}
+		return nil
+	}
+}
+
+/**
+ * TimeoutError is recorded on a TeardownEntry when its registered func did not return before its
+ * deadline. The func's goroutine is abandoned (Go has no way to forcibly cancel it) so that one
+ * hung teardown cannot block the rest of the chain.
+ */
+type TimeoutError struct {
+	Name    string
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("teardown: %q did not complete within %s", e.Name, e.Timeout)
+}
+
+/**
+ * runEntryCtx invokes entry.fn, recovering any panic, and waits for it to finish before returning -
+ * which is what preserves LIFO ordering across entries in Run/RunWithContext.
+ *
+ * A plain (non-ctx-aware) entry is run synchronously in the caller's own goroutine: these are
+ * typically registered from inside a test, and a t.FailNow()/require.* call triggers
+ * runtime.Goexit, which would never reach a done channel from a separate goroutine - it would
+ * instead hang until entry.timeout and wrongly report a TimeoutError. Running it synchronously
+ * also preserves the baseline's unbounded, not-silently-abandoned execution for plain teardowns.
+ *
+ * Only entries registered via AddCtx/AddDiagnosticCtx are ctx-aware, and only those get the
+ * goroutine+deadline treatment, since they're expected to honour ctx and return an error rather
+ * than call Goexit.
+ */
+func runEntryCtx(ctx context.Context, name string, entry ctxTeardownFunc) TeardownEntry {
+	start := time.Now()
+
+	if !entry.ctxAware {
+		err := runProtectedCtx(ctx, entry.fn)
+		return TeardownEntry{Name: name, Elapsed: time.Since(start), Err: err}
+	}
+
+	var cctx context.Context
+	var cancel context.CancelFunc
+	if entry.timeout > 0 {
+		cctx, cancel = context.WithTimeout(ctx, entry.timeout)
+	} else {
+		cctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- runProtectedCtx(cctx, entry.fn)
+	}()
+
+	select {
+	case err := <-done:
+		return TeardownEntry{Name: name, Elapsed: time.Since(start), Err: err}
+
+	case <-cctx.Done():
+		return TeardownEntry{Name: name, Elapsed: time.Since(start), Err: &TimeoutError{Name: name, Timeout: entry.timeout}}
+	}
+}
+
+/** runProtectedCtx calls fn, recovering any panic and reporting it as an error. */
+func runProtectedCtx(ctx context.Context, fn func(context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("teardown: recovered panic: %v", r)
+		}
+	}()
+
+	return fn(ctx)
+}
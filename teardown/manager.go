@@ -0,0 +1,326 @@
+package teardown
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+/**
+ * Manager owns its own teardownLists/diagnosticTeardownLists, so that parallel subtests -
+ * each running its own Manager - can register DB/resource teardowns without cross-contaminating
+ * each other, the way a package-level map would.
+ *
+ * The package-level AddTeardown/Teardown/... functions are a thin wrapper around a default
+ * Manager, kept for backward compatibility; new code that needs isolation (e.g. t.Parallel())
+ * should create its own Manager with NewManager().
+ */
+type Manager struct {
+	mu                      sync.Mutex
+	teardownLists           map[string][]ctxTeardownFunc
+	diagnosticTeardownLists map[string][]ctxTeardownFunc
+	toredownNames           map[string]bool
+	skippedNames            map[string]bool
+	skipMode                SkipMode
+	skipCondition           interface{}
+	bound                   map[testing.TB]map[string]bool
+	children                []*Manager
+}
+
+/** NewManager creates an empty, ready-to-use Manager. */
+func NewManager() *Manager {
+	return &Manager{
+		teardownLists:           make(map[string][]ctxTeardownFunc),
+		diagnosticTeardownLists: make(map[string][]ctxTeardownFunc),
+		toredownNames:           make(map[string]bool),
+		skippedNames:            make(map[string]bool),
+		bound:                   make(map[testing.TB]map[string]bool),
+	}
+}
+
+/**
+ * Child creates a nested Manager with its own independent scopes, for hierarchical teardown
+ * (e.g. suite -> test -> subtest). The child is NOT required to be run explicitly: m tracks it
+ * directly (rather than via AddGlobal against whatever names happen to exist yet), so anything
+ * still left in the child is run as a safety net whenever any scope on m tears down - regardless
+ * of whether the child was created before or after that scope was registered.
+ *
+ * The typical idiom for a parallel subtest is:
+ * <pre>
+ *   child := parentMgr.Child()
+ *   defer child.Run("")
+ * <pre>
+ */
+func (m *Manager) Child() *Manager {
+	child := NewManager()
+
+	m.mu.Lock()
+	m.children = append(m.children, child)
+	m.mu.Unlock()
+
+	return child
+}
+
+/** runChildren runs runAllRemaining on every Manager created via m.Child(), in no particular order. */
+func (m *Manager) runChildren() {
+	m.mu.Lock()
+	children := append([]*Manager{}, m.children...)
+	m.mu.Unlock()
+
+	for _, child := range children {
+		child.runAllRemaining()
+	}
+}
+
+/** runAllRemaining tears down every list still registered on m, in no particular order across lists. */
+func (m *Manager) runAllRemaining() {
+	m.mu.Lock()
+	names := make(map[string]bool)
+	for name := range m.teardownLists {
+		names[name] = true
+	}
+	for name := range m.diagnosticTeardownLists {
+		names[name] = true
+	}
+	m.mu.Unlock()
+
+	for name := range names {
+		m.Run(name)
+	}
+}
+
+/**
+ * Add registers a teardown function to the named list - see AddTeardown for full semantics. Unlike
+ * AddCtx, the func is run synchronously (not under a goroutine+deadline) when the list tears down,
+ * so a teardown that calls t.FailNow()/require.* behaves exactly as it would under a plain defer.
+ */
+func (m *Manager) Add(name string, teardownFunc func()) {
+	entry := newPlainEntry(adaptPlainFunc(teardownFunc))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.teardownLists[name] = append(m.teardownLists[name], entry)
+	delete(m.toredownNames, name)
+}
+
+/**
+ * AddCtx registers a context-aware teardown function to the named list - see AddTeardownCtx for
+ * full semantics, including the per-function deadline controlled by WithTimeout/TEARDOWN_TIMEOUT.
+ * It can be mixed freely with funcs registered via Add in the same list; only AddCtx entries run
+ * under a goroutine+deadline, while Add entries still run synchronously.
+ */
+func (m *Manager) AddCtx(name string, teardownFunc func(context.Context) error, opts ...Option) {
+	entry := newCtxEntry(teardownFunc, opts...)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.teardownLists[name] = append(m.teardownLists[name], entry)
+	delete(m.toredownNames, name)
+}
+
+/**
+ * AddGlobal registers a teardown function against every name that currently has a (non-diagnostic)
+ * teardown list on this Manager; names with only diagnostic teardowns are not included.
+ */
+func (m *Manager) AddGlobal(teardownFunc func()) {
+	m.mu.Lock()
+	names := make([]string, 0, len(m.teardownLists))
+	for name := range m.teardownLists {
+		names = append(names, name)
+	}
+	m.mu.Unlock()
+
+	for _, name := range names {
+		m.Add(name, teardownFunc)
+	}
+}
+
+/**
+ * AddDiagnostic registers a diagnostic teardown func - see AddDiagnosticTeardown for full
+ * semantics. Like Add, the func runs synchronously rather than under a goroutine+deadline.
+ */
+func (m *Manager) AddDiagnostic(name string, condition interface{}, teardownFunc func()) {
+	entry := newPlainEntry(wrapDiagnosticCtx(condition, adaptPlainFunc(teardownFunc)))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.diagnosticTeardownLists[name] = append(m.diagnosticTeardownLists[name], entry)
+	delete(m.toredownNames, name)
+}
+
+/**
+ * AddDiagnosticCtx is the context-aware counterpart of AddDiagnostic - see AddDiagnosticTeardownCtx
+ * for full semantics.
+ */
+func (m *Manager) AddDiagnosticCtx(name string, condition interface{}, teardownFunc func(context.Context) error, opts ...Option) {
+	entry := newCtxEntry(wrapDiagnosticCtx(condition, teardownFunc), opts...)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.diagnosticTeardownLists[name] = append(m.diagnosticTeardownLists[name], entry)
+	delete(m.toredownNames, name)
+}
+
+/** AddGlobalDiagnostic registers a diagnostic teardown func against every diagnostic name currently known to this Manager. */
+func (m *Manager) AddGlobalDiagnostic(condition interface{}, teardownFunc func()) {
+	m.mu.Lock()
+	names := make([]string, 0, len(m.diagnosticTeardownLists))
+	for name := range m.diagnosticTeardownLists {
+		names = append(names, name)
+	}
+	m.mu.Unlock()
+
+	for _, name := range names {
+		m.AddDiagnostic(name, condition, teardownFunc)
+	}
+}
+
+/**
+ * SetSkipMode controls whether Run(name) actually invokes the registered non-diagnostic teardown
+ * funcs for this Manager - see SetSkipMode for full semantics. Diagnostic teardowns are unaffected
+ * and always run according to their own condition.
+ */
+func (m *Manager) SetSkipMode(mode SkipMode, condition interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.skipMode = mode
+	m.skipCondition = condition
+}
+
+/**
+ * Run tears down the named list - see Teardown for full semantics, including idempotency and panic
+ * recovery. If the current SkipMode resolves to skip, the registered (non-diagnostic) teardown
+ * funcs are left in place rather than run, so a developer can inspect the leaked resource; the
+ * list is reported separately by Verify until it is genuinely run.
+ *
+ * Equivalent to RunWithContext(context.Background(), name).
+ */
+func (m *Manager) Run(name string) TeardownResult {
+	return m.RunWithContext(context.Background(), name)
+}
+
+/**
+ * RunWithContext is the context-aware counterpart of Run - see RunWithContext for full semantics,
+ * including per-function deadlines and TimeoutError.
+ */
+func (m *Manager) RunWithContext(ctx context.Context, name string) TeardownResult {
+	m.mu.Lock()
+
+	if m.toredownNames[name] {
+		m.mu.Unlock()
+		return TeardownResult{}
+	}
+
+	diagList := append([]ctxTeardownFunc{}, m.diagnosticTeardownLists[name]...)
+	delete(m.diagnosticTeardownLists, name)
+
+	skip := shouldSkipTeardown(m.skipMode, m.skipCondition)
+
+	var teardownList []ctxTeardownFunc
+	if skip {
+		m.skippedNames[name] = true
+	} else {
+		teardownList = append([]ctxTeardownFunc{}, m.teardownLists[name]...)
+		delete(m.teardownLists, name)
+		delete(m.skippedNames, name)
+		m.toredownNames[name] = true
+	}
+
+	m.mu.Unlock()
+
+	result := TeardownResult{Skipped: skip}
+
+	// diagnostic teardowns run first, and always run regardless of skip mode; each entry (or its
+	// timeout) is waited on before the next starts, preserving LIFO ordering.
+	for x := len(diagList) - 1; x >= 0; x-- {
+		result.Entries = append(result.Entries, runEntryCtx(ctx, name, diagList[x]))
+	}
+
+	for x := len(teardownList) - 1; x >= 0; x-- {
+		result.Entries = append(result.Entries, runEntryCtx(ctx, name, teardownList[x]))
+	}
+
+	m.runChildren()
+
+	return result
+}
+
+/**
+ * Verify tears down any lists still remaining on m, and fails t if any were found - see
+ * VerifyTeardown. Lists left behind because a SkipMode skipped them are reported separately from
+ * genuinely uncleared lists, so CI can still fail loudly if skip mode is left on by mistake.
+ */
+func (m *Manager) Verify(t *testing.T) {
+	m.mu.Lock()
+
+	for name, list := range m.diagnosticTeardownLists {
+		m.teardownLists[name] = append(m.teardownLists[name], list...)
+	}
+
+	uncleared := make([]string, 0)
+	skipped := make([]string, 0)
+	remaining := make(map[string][]ctxTeardownFunc, len(m.teardownLists))
+
+	for name, list := range m.teardownLists {
+		if m.skippedNames[name] {
+			skipped = append(skipped, name)
+		} else {
+			uncleared = append(uncleared, name)
+		}
+
+		remaining[name] = append([]ctxTeardownFunc{}, list...)
+	}
+
+	m.teardownLists = make(map[string][]ctxTeardownFunc)
+	m.diagnosticTeardownLists = make(map[string][]ctxTeardownFunc)
+	m.toredownNames = make(map[string]bool)
+	m.skippedNames = make(map[string]bool)
+
+	m.mu.Unlock()
+
+	for name, list := range remaining {
+		for x := len(list) - 1; x >= 0; x-- {
+			runEntryCtx(context.Background(), name, list[x])
+		}
+	}
+
+	m.runChildren()
+
+	if t == nil {
+		return
+	}
+
+	if len(skipped) > 0 {
+		t.Errorf("Error - %d teardownLists were left registered by a skip mode: %s", len(skipped), skipped)
+	}
+
+	if len(uncleared) > 0 {
+		t.Fatalf("Error - %d teardownLists were left uncleared: %s", len(uncleared), uncleared)
+	}
+}
+
+/**
+ * evalCondition interprets the condition values accepted by AddDiagnosticTeardown and
+ * SetSkipMode(SkipTeardownOnFailure, ...): a *testing.T (true if failed), a func() bool, a plain
+ * bool, or - as a fallback - any other non-nil value.
+ */
+func evalCondition(condition interface{}) bool {
+	switch c := condition.(type) {
+	case *testing.T:
+		return c.Failed()
+
+	case func() bool:
+		return c()
+
+	case bool:
+		return c
+
+	default:
+		return c != nil
+	}
+}
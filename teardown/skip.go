@@ -0,0 +1,67 @@
+package teardown
+
+import (
+	"os"
+	"strings"
+)
+
+/** SkipMode controls whether a Manager's Run(name) actually invokes registered teardown funcs. */
+type SkipMode int
+
+const (
+	// SkipTeardownNever runs every registered teardown func - the default.
+	SkipTeardownNever SkipMode = iota
+
+	// SkipTeardownAlways leaves every registered (non-diagnostic) teardown func un-run, so
+	// leaked containers/DBs/etc can be inspected after the test process exits.
+	SkipTeardownAlways
+
+	// SkipTeardownOnFailure leaves teardown un-run only when its condition resolves true -
+	// typically a *testing.T that has failed - so a failing test's resources can be inspected
+	// while passing tests still clean up normally.
+	SkipTeardownOnFailure
+)
+
+/** TEARDOWN_SKIP_MODE selects the default SkipMode at init time, e.g. TEARDOWN_SKIP_MODE=ALWAYS. */
+const TEARDOWN_SKIP_MODE = "TEARDOWN_SKIP_MODE"
+
+func init() {
+	if mode, ok := parseSkipMode(os.Getenv(TEARDOWN_SKIP_MODE)); ok {
+		defaultManager.SetSkipMode(mode, nil)
+	}
+}
+
+/**
+ * parseSkipMode maps the TEARDOWN_SKIP_MODE env var to a SkipMode. It has no way to supply a
+ * per-test condition (a *testing.T isn't available at init time), so SkipTeardownOnFailure set
+ * this way only takes effect once the test also calls SetSkipMode with a real condition.
+ */
+func parseSkipMode(s string) (SkipMode, bool) {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "ALWAYS":
+		return SkipTeardownAlways, true
+
+	case "ON_FAILURE", "ONFAILURE":
+		return SkipTeardownOnFailure, true
+
+	case "NEVER":
+		return SkipTeardownNever, true
+
+	default:
+		return SkipTeardownNever, false
+	}
+}
+
+/** shouldSkipTeardown resolves mode/condition to a yes-or-no decision for a single Run(name) call. */
+func shouldSkipTeardown(mode SkipMode, condition interface{}) bool {
+	switch mode {
+	case SkipTeardownAlways:
+		return true
+
+	case SkipTeardownOnFailure:
+		return evalCondition(condition)
+
+	default:
+		return false
+	}
+}
@@ -1,20 +1,68 @@
 package teardown
 
 import (
+	"context"
 	"os"
 	"strings"
 	"testing"
+	"time"
 )
 
-/** Lists of the teardown and diagnostic teardown funcs */
-var teardownLists = make(map[string][]func())
-var diagnosticTeardownLists = make(map[string][]func())
-
 const ALWAYS_RUN_DIAGNOSTIC_TEARDOWNS = "ALWAYS_RUN_DIAGNOSTIC_TEARDOWNS"
 
 /** Exported var - initialised from the EnvVar, but can be reset in code if desired */
 var AlwaysRunDiagnosticTeardowns = strings.EqualFold(os.Getenv(ALWAYS_RUN_DIAGNOSTIC_TEARDOWNS), "true")
 
+/** defaultManager backs the package-level functions below, for callers that don't need an isolated Manager. */
+var defaultManager = NewManager()
+
+/**
+ * TeardownEntry records the outcome of a single registered teardown func, as run by Teardown(name)
+ * or (*Manager).Run(name).
+ *
+ * Err is set if the func panicked, returned an error (for AddTeardownCtx-style funcs), or did not
+ * complete before its deadline (a *TimeoutError).
+ */
+type TeardownEntry struct {
+	Name    string
+	Elapsed time.Duration
+	Err     error
+}
+
+/**
+ * TeardownResult is the structured outcome of a call to Teardown(name) - one TeardownEntry per
+ * registered func that was called, in the order they were called (LIFO). A diagnostic func whose
+ * condition resolved false still has an entry, although it did nothing.
+ *
+ * Modelled loosely on Haskell's Control.Teardown: callers that care whether cleanup fully
+ * succeeded can inspect DidFail()/FailedCount() rather than rely on teardown funcs never panicking.
+ */
+type TeardownResult struct {
+	Entries []TeardownEntry
+	Skipped bool
+}
+
+/** DidFail reports whether any entry in this result recorded an error or recovered panic. */
+func (r TeardownResult) DidFail() bool {
+	return r.FailedCount() > 0
+}
+
+/** FailedCount returns the number of entries that recorded an error or recovered panic. */
+func (r TeardownResult) FailedCount() int {
+	count := 0
+	for _, e := range r.Entries {
+		if e.Err != nil {
+			count++
+		}
+	}
+	return count
+}
+
+/** ToredownCount returns the number of entries in this result - see TeardownResult for which funcs have one. */
+func (r TeardownResult) ToredownCount() int {
+	return len(r.Entries)
+}
+
 /**
  * add a teardown function to the named list - for deferred execution.
  *
@@ -26,9 +74,16 @@ var AlwaysRunDiagnosticTeardowns = strings.EqualFold(os.Getenv(ALWAYS_RUN_DIAGNO
  *   // possibly more teardown.AddTeardown("DATABASE", func() { ... })
  *   defer teardown.Teardown("DATABASE")
  * <pre>
+ *
+ * For parallel subtests that each need their own isolated scope, prefer a dedicated Manager:
+ * <pre>
+ *   mgr := teardown.NewManager()
+ *   defer mgr.Run("DATABASE")
+ *   mgr.Add("DATABASE", func() { ... })
+ * <pre>
  */
 func AddTeardown(name string, teardownFunc func()) {
-	teardownLists[name] = append(teardownLists[name], teardownFunc)
+	defaultManager.Add(name, teardownFunc)
 }
 
 /**
@@ -38,9 +93,7 @@ func AddTeardown(name string, teardownFunc func()) {
  *
  */
 func AddGlobalTeardown(teardownFunc func()) {
-	for name := range teardownLists {
-		AddTeardown(name, teardownFunc)
-	}
+	defaultManager.AddGlobal(teardownFunc)
 }
 
 /**
@@ -57,35 +110,24 @@ func AddGlobalTeardown(teardownFunc func()) {
  * intermediate states.
  */
 func AddDiagnosticTeardown(name string, condition interface{}, teardownFunc func()) {
+	defaultManager.AddDiagnostic(name, condition, teardownFunc)
+}
 
-	// the test for whether to run the diagnostic teardown must be executed at TEARDOWN time, not at DEFER time.
-	// So, create a wrapper func that has the logic to determine whether to run the teardown func, and calls it conditionally.
-	tdfunc := func() {
-		shouldIdoIt := AlwaysRunDiagnosticTeardowns
-
-		if !shouldIdoIt {
-			switch c := condition.(type) {
-			case *testing.T:
-				shouldIdoIt = c.Failed()
-
-			case func() bool:
-				shouldIdoIt = c()
-
-			case bool:
-				shouldIdoIt = c
-
-			default:
-				shouldIdoIt = c != nil
-			}
-		}
-
-		if shouldIdoIt {
-			teardownFunc()
-		}
-	}
-
-	// add the wrapper func to the diagnosticTeardown map
-	diagnosticTeardownLists[name] = append(diagnosticTeardownLists[name], tdfunc)
+/**
+ * AddTeardownCtx is the context-aware counterpart of AddTeardown, for teardowns that can hang
+ * (closing a DB, tearing down a k8s namespace, stopping a container). fn is invoked in its own
+ * goroutine under a context carrying a per-function deadline - WithTimeout(d) overrides it for
+ * this entry, otherwise the TEARDOWN_TIMEOUT env var (or a built-in default) applies. If the
+ * deadline fires first, the entry's TeardownEntry.Err is a *TimeoutError and the chain continues
+ * with the next function rather than blocking on the hung one.
+ *
+ * Funcs registered with AddTeardownCtx and plain AddTeardown can be mixed freely in the same
+ * named list. Only AddTeardownCtx entries get the goroutine+deadline treatment; a plain
+ * AddTeardown func still runs synchronously, exactly as it would under a bare defer, so that
+ * t.FailNow()/require.* inside it behaves as expected instead of hanging until the deadline.
+ */
+func AddTeardownCtx(name string, teardownFunc func(context.Context) error, opts ...Option) {
+	defaultManager.AddCtx(name, teardownFunc, opts...)
 }
 
 /**
@@ -95,31 +137,41 @@ func AddDiagnosticTeardown(name string, condition interface{}, teardownFunc func
  *
  */
 func AddGlobalDiagnosticTeardown(condition interface{}, teardownFunc func()) {
-	for name := range diagnosticTeardownLists {
-		AddDiagnosticTeardown(name, condition, teardownFunc)
-	}
+	defaultManager.AddGlobalDiagnostic(condition, teardownFunc)
+}
+
+/** AddDiagnosticTeardownCtx is the context-aware counterpart of AddDiagnosticTeardown - see AddTeardownCtx for the deadline semantics. */
+func AddDiagnosticTeardownCtx(name string, condition interface{}, teardownFunc func(context.Context) error, opts ...Option) {
+	defaultManager.AddDiagnosticCtx(name, condition, teardownFunc, opts...)
 }
 
 /**
- * Call the stored teardown functions in the named list, in the correct order (last-in-first-out)
+ * Call the stored teardown functions in the named list, in the correct order (last-in-first-out),
+ * returning a TeardownResult describing what ran.
  *
  * NOTE: Any DIAGNOSTIC teardowns - those added with AddDiagnosticTeardown() for this name - are called BEFORE any other teardowns for this name.
  *
+ * Teardown(name) is idempotent: once a name has been torn down, a later call with the same name
+ * is a no-op and returns an empty TeardownResult, rather than silently operating on a fresh empty list.
+ * A panic (or, for context-aware variants, an error) from one registered func does not prevent the
+ * remaining funcs from running - each is invoked under its own defer/recover, and any failure is
+ * recorded on the corresponding TeardownEntry instead of propagating.
+ *
  * The typical use of Teardown is with a deferred call:
  * defer teardown.Teardown("SOME NAME")
  * See: teardown.AddTeardown(); teardown.AddDiagnosticTeardown()
  */
-func Teardown(name string) {
-	// ensure both list and diagnostic list are removed.
-	defer func() { delete(diagnosticTeardownLists, name) }()
-	defer func() { delete(teardownLists, name) }()
-
-	list := teardownLists[name]
-	list = append(list, diagnosticTeardownLists[name]...) // append any diagnostic funcs - so they are called FIRST
+func Teardown(name string) TeardownResult {
+	return defaultManager.Run(name)
+}
 
-	for x := len(list) - 1; x >= 0; x-- {
-		list[x]()
-	}
+/**
+ * RunWithContext is the context-aware counterpart of Teardown(name): ctx is the parent for each
+ * registered func's per-function deadline (see AddTeardownCtx), so cancelling ctx cancels every
+ * not-yet-started func's deadline context too.
+ */
+func RunWithContext(ctx context.Context, name string) TeardownResult {
+	return defaultManager.RunWithContext(ctx, name)
 }
 
 /**
@@ -132,29 +184,17 @@ func Teardown(name string) {
 * This function MUST NOT be used as a replacement for calling teardown() at the correct point in the code.
  */
 func VerifyTeardown(t *testing.T) {
+	defaultManager.Verify(t)
+}
 
-	// ensure all funcs in all lists are released
-	defer func() { teardownLists = make(map[string][]func()) }()
-	defer func() { diagnosticTeardownLists = make(map[string][]func()) }()
-
-	// append each diagnostic list to the corresponding (possibly empty) teardown list
-	for name, list := range diagnosticTeardownLists {
-		teardownLists[name] = append(teardownLists[name], list...)
-	}
-
-	// release all remaining resources - this is a "best effort" as the order of iterating the map is arbitrary
-	uncleared := make([]string, 0)
-
-	// make a "best-effort" at releasing all remaining resources
-	for name, list := range teardownLists {
-		uncleared = append(uncleared, name)
-
-		for x := len(list) - 1; x >= 0; x-- {
-			list[x]()
-		}
-	}
-
-	if len(uncleared) > 0 && t != nil {
-		t.Fatalf("Error - %d teardownLists were left uncleared: %s", len(uncleared), uncleared)
-	}
+/**
+ * SetSkipMode controls whether Teardown(name) actually invokes the registered non-diagnostic
+ * teardown funcs - see the SkipMode constants for the available modes. Diagnostic teardowns
+ * (AddDiagnosticTeardown) are unaffected and keep running according to their own condition.
+ *
+ * condition is only consulted for SkipTeardownOnFailure, and accepts the same values as
+ * AddDiagnosticTeardown: a *testing.T, a func() bool, or a bool.
+ */
+func SetSkipMode(mode SkipMode, condition interface{}) {
+	defaultManager.SetSkipMode(mode, condition)
 }
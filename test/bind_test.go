@@ -0,0 +1,68 @@
+package test
+
+import (
+	"testing"
+
+	"niktj777/teardown/teardown"
+
+	"github.com/stretchr/testify/require"
+)
+
+/* verify that AddT registers fn and that it runs automatically via t.Cleanup, without an explicit Teardown call */
+func TestAddTRunsOnCleanup(t *testing.T) {
+	mgr := teardown.NewManager()
+	ran := false
+
+	t.Run("subtest", func(t *testing.T) {
+		mgr.AddT(t, "", func() { ran = true })
+		require.False(t, ran, "teardown must not run until the subtest's Cleanup fires")
+	})
+
+	require.True(t, ran, "teardown must have run once the subtest completed")
+}
+
+/* verify that Bind only registers a single t.Cleanup per (t, name) pair, even if AddT is called many times */
+func TestBindIsOncePerNamePair(t *testing.T) {
+	mgr := teardown.NewManager()
+	ran := 0
+
+	t.Run("subtest", func(t *testing.T) {
+		mgr.AddT(t, "shared", func() { ran++ })
+		mgr.AddT(t, "shared", func() { ran++ })
+		mgr.Bind(t, "shared")
+		mgr.Bind(t, "shared")
+	})
+
+	require.Equal(t, 2, ran, "both registered funcs should run exactly once each, via a single bound Cleanup")
+}
+
+/* verify that an empty name resolves to t.Name(), so different subtests don't collide on the default name */
+func TestAddTEmptyNameUsesTName(t *testing.T) {
+	mgr := teardown.NewManager()
+	ranA, ranB := false, false
+
+	t.Run("a", func(t *testing.T) {
+		mgr.AddT(t, "", func() { ranA = true })
+	})
+
+	t.Run("b", func(t *testing.T) {
+		mgr.AddT(t, "", func() { ranB = true })
+	})
+
+	require.True(t, ranA)
+	require.True(t, ranB)
+}
+
+/* verify that AddDiagnosticT uses t itself as the condition, running only once t has failed */
+func TestAddDiagnosticTUsesTAsCondition(t *testing.T) {
+	mgr := teardown.NewManager()
+	diagRan := false
+
+	tt := new(testing.T)
+	mgr.AddDiagnosticT(tt, "name", func() { diagRan = true })
+
+	mgr.Run("name")
+	require.False(t, diagRan, "diagnostic must not run while t has not failed")
+
+	mgr.Verify(t)
+}
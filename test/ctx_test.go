@@ -0,0 +1,126 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"niktj777/teardown/teardown"
+
+	"github.com/stretchr/testify/require"
+)
+
+/* verify that a context-aware teardown func runs normally and its error is recorded on the entry */
+func TestAddTeardownCtxRunsAndRecordsError(t *testing.T) {
+	mgr := teardown.NewManager()
+
+	errClose := errors.New("close failed")
+	ran := false
+
+	mgr.AddCtx("name", func(ctx context.Context) error {
+		ran = true
+		return errClose
+	})
+
+	result := mgr.RunWithContext(context.Background(), "name")
+
+	require.True(t, ran)
+	require.Equal(t, 1, result.ToredownCount())
+	require.True(t, result.DidFail())
+	require.ErrorIs(t, result.Entries[0].Err, errClose)
+
+	mgr.Verify(t)
+}
+
+/* verify that a hung teardown func is recorded as a TimeoutError and does not block the rest of the chain */
+func TestAddTeardownCtxTimesOut(t *testing.T) {
+	mgr := teardown.NewManager()
+
+	secondRan := false
+
+	// registered first so that it runs after the hung func (LIFO)
+	mgr.Add("name", func() {
+		secondRan = true
+	})
+
+	mgr.AddCtx("name", func(ctx context.Context) error {
+		<-ctx.Done() // simulate a hung teardown that only returns once its context is done
+		return nil
+	}, teardown.WithTimeout(10*time.Millisecond))
+
+	start := time.Now()
+	result := mgr.RunWithContext(context.Background(), "name")
+	elapsed := time.Since(start)
+
+	require.True(t, secondRan, "a hung func must not block the rest of the teardown chain")
+	require.Less(t, elapsed, 2*time.Second)
+	require.True(t, result.DidFail())
+
+	var timeoutErr *teardown.TimeoutError
+	found := false
+	for _, e := range result.Entries {
+		if te, ok := e.Err.(*teardown.TimeoutError); ok {
+			timeoutErr = te
+			found = true
+		}
+	}
+	require.True(t, found, "expected one entry to carry a *TimeoutError")
+	require.Equal(t, "name", timeoutErr.Name)
+
+	mgr.Verify(t)
+}
+
+/*
+ * verify that a plain Add teardown calling t.FailNow() (as require.* does internally) fails fast
+ * instead of hanging for the goroutine+deadline treatment reserved for AddCtx entries - a plain
+ * func() never reaches a done channel after runtime.Goexit, so running it under that path would
+ * wrongly report a TimeoutError after the full default timeout.
+ */
+func TestPlainTeardownFailNowDoesNotHang(t *testing.T) {
+	mgr := teardown.NewManager()
+
+	// runtime.Goexit is what t.FailNow()/require.* call internally; simulate it directly rather
+	// than via a real *testing.T, so this test doesn't itself get reported as a failure.
+	mgr.Add("name", func() {
+		runtime.Goexit()
+	})
+
+	start := time.Now()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		mgr.Run("name")
+	}()
+
+	select {
+	case <-done:
+		require.Less(t, time.Since(start), time.Second, "a plain teardown calling runtime.Goexit must not be delayed by the ctx-aware deadline path")
+	case <-time.After(time.Second):
+		t.Fatal("Run(name) did not return promptly after a plain teardown called runtime.Goexit")
+	}
+}
+
+/* verify that plain func()-style entries and context-aware entries can be mixed in the same list */
+func TestMixedPlainAndCtxEntries(t *testing.T) {
+	mgr := teardown.NewManager()
+
+	order := []string{}
+
+	mgr.Add("name", func() {
+		order = append(order, "plain")
+	})
+
+	mgr.AddCtx("name", func(ctx context.Context) error {
+		order = append(order, "ctx")
+		return nil
+	})
+
+	result := mgr.RunWithContext(context.Background(), "name")
+
+	require.Equal(t, []string{"ctx", "plain"}, order, "last-registered entry runs first, regardless of which Add variant registered it")
+	require.Equal(t, 2, result.ToredownCount())
+
+	mgr.Verify(t)
+}
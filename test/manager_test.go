@@ -0,0 +1,165 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"niktj777/teardown/teardown"
+
+	"github.com/stretchr/testify/require"
+)
+
+/* verify that two independent Managers do not cross-contaminate each other's lists -
+ * the scenario that makes t.Parallel() subtests unsafe with the package-level API */
+func TestManagerIsolation(t *testing.T) {
+	mgrA := teardown.NewManager()
+	mgrB := teardown.NewManager()
+
+	ranA, ranB := 0, 0
+
+	mgrA.Add("name", func() { ranA++ })
+	mgrB.Add("name", func() { ranB++ })
+
+	mgrA.Run("name")
+
+	require.Equal(t, 1, ranA)
+	require.Equal(t, 0, ranB, "tearing down mgrA must not affect mgrB's list of the same name")
+
+	mgrB.Run("name")
+	require.Equal(t, 1, ranB)
+
+	mgrA.Verify(t)
+	mgrB.Verify(t)
+}
+
+/* verify that Manager.Add/Run/Verify mirror the semantics of the package-level functions */
+func TestManagerMirrorsPackageAPI(t *testing.T) {
+	mgr := teardown.NewManager()
+	tdcounter := 0
+
+	mgr.Add("name", func() {
+		tdcounter++
+		require.Equal(t, 3, tdcounter)
+	})
+
+	mgr.Add("name", func() {
+		tdcounter++
+		require.Equal(t, 2, tdcounter)
+	})
+
+	mgr.AddDiagnostic("name", true, func() {
+		tdcounter++
+		require.Equal(t, 1, tdcounter)
+	})
+
+	result := mgr.Run("name")
+
+	require.Equal(t, 3, tdcounter)
+	require.Equal(t, 3, result.ToredownCount())
+	require.False(t, result.DidFail())
+
+	mgr.Verify(t)
+}
+
+/* verify that Child() produces a nested Manager that is automatically torn down when the parent is */
+func TestManagerChildTornDownWithParent(t *testing.T) {
+	parent := teardown.NewManager()
+	parent.Add("SUITE", func() {})
+	child := parent.Child()
+
+	childRan := false
+	child.Add("SUBTEST", func() { childRan = true })
+
+	parent.Run("SUITE")
+
+	require.True(t, childRan, "child teardown should run automatically once the parent tears down")
+
+	parent.Verify(t)
+	child.Verify(t)
+}
+
+/* verify that a child created before the parent has registered any scope is still torn down -
+ * the child must not be lost just because it pre-dates the parent's first Add */
+func TestManagerChildCreatedBeforeParentScopeExists(t *testing.T) {
+	parent := teardown.NewManager()
+	child := parent.Child()
+
+	childRan := false
+	child.Add("SUBTEST", func() { childRan = true })
+
+	parent.Add("SUITE", func() {})
+	parent.Run("SUITE")
+
+	require.True(t, childRan, "child teardown should run even though it was created before the parent registered SUITE")
+
+	parent.Verify(t)
+	child.Verify(t)
+}
+
+/* verify that an explicit Run on the child before the parent tears down is respected (idempotent, no double-run) */
+func TestManagerChildExplicitRunIsRespected(t *testing.T) {
+	parent := teardown.NewManager()
+	parent.Add("SUITE", func() {})
+	child := parent.Child()
+
+	ran := 0
+	child.Add("SUBTEST", func() { ran++ })
+
+	child.Run("SUBTEST")
+	require.Equal(t, 1, ran)
+
+	parent.Run("SUITE")
+	require.Equal(t, 1, ran, "child teardown must not run twice once already torn down explicitly")
+
+	parent.Verify(t)
+	child.Verify(t)
+}
+
+/* verify that a teardown func left uncleared at Verify time can itself re-enter the same Manager
+ * (e.g. to Add a follow-up teardown) without self-deadlocking on m.mu */
+func TestManagerVerifyAllowsReentrantAdd(t *testing.T) {
+	mgr := teardown.NewManager()
+
+	reentered := false
+
+	mgr.Add("name", func() {
+		mgr.Add("other", func() { reentered = true })
+	})
+
+	mgr.Verify(nil)
+	mgr.Verify(nil)
+
+	require.True(t, reentered, "a teardown func registered via Add must be able to Add to the same Manager")
+}
+
+/* verify that a hung AddCtx entry left uncleared at Verify time does not pin m.mu for its timeout,
+ * which would otherwise block a concurrent Add/Run from a parallel subtest on the same Manager */
+func TestManagerVerifyDoesNotBlockConcurrentAddOnHungEntry(t *testing.T) {
+	mgr := teardown.NewManager()
+
+	mgr.AddCtx("name", func(ctx context.Context) error {
+		<-ctx.Done() // simulate a hung teardown that only returns once its context is done
+		return nil
+	}, teardown.WithTimeout(time.Hour))
+
+	verifyDone := make(chan struct{})
+	go func() {
+		defer close(verifyDone)
+		mgr.Verify(nil)
+	}()
+
+	addDone := make(chan struct{})
+	go func() {
+		defer close(addDone)
+		mgr.Add("other", func() {})
+	}()
+
+	select {
+	case <-addDone:
+	case <-time.After(time.Second):
+		t.Fatal("Add blocked on m.mu while Verify was running a hung entry")
+	}
+
+	mgr.Run("other")
+}
@@ -0,0 +1,79 @@
+package test
+
+import (
+	"testing"
+
+	"niktj777/teardown/teardown"
+
+	"github.com/stretchr/testify/require"
+)
+
+/* verify that SkipTeardownAlways leaves registered teardown funcs un-run, while diagnostic teardowns still run */
+func TestSkipTeardownAlways(t *testing.T) {
+	mgr := teardown.NewManager()
+	mgr.SetSkipMode(teardown.SkipTeardownAlways, nil)
+
+	ran, diagRan := false, false
+	mgr.Add("name", func() { ran = true })
+	mgr.AddDiagnostic("name", true, func() { diagRan = true })
+
+	result := mgr.Run("name")
+
+	require.False(t, ran, "teardown func should have been skipped")
+	require.True(t, diagRan, "diagnostic teardown must still run regardless of skip mode")
+	require.True(t, result.Skipped)
+
+	// the skipped-but-registered list must still be reported distinctly by Verify, and
+	// actually released as a best effort
+	tt := new(testing.T)
+	mgr.Verify(tt)
+	require.True(t, tt.Failed())
+	require.True(t, ran, "Verify must still release a skipped resource as a best effort")
+}
+
+/* verify that SkipTeardownOnFailure only skips when its condition resolves true */
+func TestSkipTeardownOnFailure(t *testing.T) {
+	mgr := teardown.NewManager()
+
+	failed := true
+	mgr.SetSkipMode(teardown.SkipTeardownOnFailure, func() bool { return failed })
+
+	ran := false
+	mgr.Add("name", func() { ran = true })
+
+	result := mgr.Run("name")
+	require.False(t, ran)
+	require.True(t, result.Skipped)
+
+	mgr.Verify(new(testing.T))
+	require.True(t, ran, "Verify releases the skipped resource")
+}
+
+/* verify that SkipTeardownOnFailure runs teardown normally when its condition resolves false */
+func TestSkipTeardownOnFailureNotTriggered(t *testing.T) {
+	mgr := teardown.NewManager()
+	mgr.SetSkipMode(teardown.SkipTeardownOnFailure, func() bool { return false })
+
+	ran := false
+	mgr.Add("name", func() { ran = true })
+
+	result := mgr.Run("name")
+	require.True(t, ran)
+	require.False(t, result.Skipped)
+
+	mgr.Verify(t)
+}
+
+/* verify that SkipTeardownNever (the default) behaves exactly like Run without any skip mode set */
+func TestSkipTeardownNeverIsDefault(t *testing.T) {
+	mgr := teardown.NewManager()
+
+	ran := false
+	mgr.Add("name", func() { ran = true })
+
+	result := mgr.Run("name")
+	require.True(t, ran)
+	require.False(t, result.Skipped)
+
+	mgr.Verify(t)
+}
@@ -0,0 +1,97 @@
+package test
+
+import (
+	"sync"
+	"testing"
+
+	"niktj777/teardown/teardown"
+
+	"github.com/stretchr/testify/require"
+)
+
+/* verify that a panicking teardown func does not prevent earlier-registered funcs from running,
+ * and that the panic is recorded on the TeardownResult rather than propagating */
+func TestTeardownRecoversPanic(t *testing.T) {
+	ran := 0
+
+	teardown.AddTeardown("panicky", func() {
+		ran++
+	})
+
+	teardown.AddTeardown("panicky", func() {
+		panic("boom")
+	})
+
+	result := teardown.Teardown("panicky")
+
+	require.Equal(t, 1, ran)
+	require.True(t, result.DidFail())
+	require.Equal(t, 1, result.FailedCount())
+	require.Equal(t, 2, result.ToredownCount())
+
+	teardown.VerifyTeardown(t)
+}
+
+/* verify that Teardown(name) is idempotent - a second call for the same name is a no-op */
+func TestTeardownIsIdempotent(t *testing.T) {
+	ran := 0
+
+	teardown.AddTeardown("idempotent", func() {
+		ran++
+	})
+
+	first := teardown.Teardown("idempotent")
+	require.Equal(t, 1, ran)
+	require.Equal(t, 1, first.ToredownCount())
+
+	second := teardown.Teardown("idempotent")
+	require.Equal(t, 1, ran, "second Teardown call must not re-run already-torn-down funcs")
+	require.Equal(t, 0, second.ToredownCount())
+
+	teardown.VerifyTeardown(t)
+}
+
+/* verify that re-registering a teardown func against a name that was already torn down makes it live again */
+func TestAddTeardownAfterTeardownRunsAgain(t *testing.T) {
+	ran := 0
+
+	teardown.AddTeardown("reopened", func() {
+		ran++
+	})
+
+	teardown.Teardown("reopened")
+	require.Equal(t, 1, ran)
+
+	teardown.AddTeardown("reopened", func() {
+		ran++
+	})
+
+	result := teardown.Teardown("reopened")
+	require.Equal(t, 2, ran)
+	require.Equal(t, 1, result.ToredownCount())
+
+	teardown.VerifyTeardown(t)
+}
+
+/* verify that concurrent AddTeardown calls from goroutines (e.g. parallel subtests) do not race */
+func TestConcurrentAddTeardown(t *testing.T) {
+	const goroutines = 50
+
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			teardown.AddTeardown("concurrent", func() {})
+		}()
+	}
+
+	wg.Wait()
+
+	result := teardown.Teardown("concurrent")
+	require.Equal(t, goroutines, result.ToredownCount())
+	require.False(t, result.DidFail())
+
+	teardown.VerifyTeardown(t)
+}